@@ -0,0 +1,68 @@
+package snowflake
+
+import "testing"
+
+func TestNewSnowflakeWithConfigRejectsOversizedLayout(t *testing.T) {
+	cfg := Config{Epoch: twepoch, DatacenterBits: 8, NodeBits: 8, StepBits: 8} // 24 > 22
+	if _, err := NewSnowflakeWithConfig(cfg, 0, 0); err == nil {
+		t.Error("NewSnowflakeWithConfig with DatacenterBits+NodeBits+StepBits > 22 = nil error, want an error")
+	}
+}
+
+func TestNewSnowflakeWithConfigRejectsDatacenterIDOverflow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DatacenterBits = 3 // max datacenterID is 7
+
+	if _, err := NewSnowflakeWithConfig(cfg, 7, 0); err != nil {
+		t.Errorf("NewSnowflakeWithConfig(datacenterID=7, DatacenterBits=3) = %v, want no error", err)
+	}
+	if _, err := NewSnowflakeWithConfig(cfg, 8, 0); err == nil {
+		t.Error("NewSnowflakeWithConfig(datacenterID=8, DatacenterBits=3) = nil error, want an error")
+	}
+}
+
+func TestNewSnowflakeWithConfigRejectsWorkerIDOverflow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NodeBits = 3 // max workerID is 7
+
+	if _, err := NewSnowflakeWithConfig(cfg, 0, 7); err != nil {
+		t.Errorf("NewSnowflakeWithConfig(workerID=7, NodeBits=3) = %v, want no error", err)
+	}
+	if _, err := NewSnowflakeWithConfig(cfg, 0, 8); err == nil {
+		t.Error("NewSnowflakeWithConfig(workerID=8, NodeBits=3) = nil error, want an error")
+	}
+}
+
+// TestNewSnowflakeWithConfigCustomLayoutRoundTrip exercises a
+// non-default epoch and bit layout end to end: IDs generated with it
+// should still decompose to the right datacenterID/workerID under that
+// same layout.
+func TestNewSnowflakeWithConfigCustomLayoutRoundTrip(t *testing.T) {
+	cfg := Config{
+		Epoch:          1609459200000, // 2021-01-01T00:00:00Z in ms
+		DatacenterBits: 3,
+		NodeBits:       7,
+		StepBits:       10,
+	}
+
+	s, err := NewSnowflakeWithConfig(cfg, 5, 42)
+	if err != nil {
+		t.Fatalf("NewSnowflakeWithConfig: %v", err)
+	}
+	if s.DatacenterID() != 5 {
+		t.Errorf("DatacenterID() = %d, want 5", s.DatacenterID())
+	}
+	if s.WorkerID() != 42 {
+		t.Errorf("WorkerID() = %d, want 42", s.WorkerID())
+	}
+
+	id := s.NextID()
+
+	parsed := s.ParseID(id)
+	if parsed.DatacenterID != 5 {
+		t.Errorf("ParseID(id).DatacenterID = %d, want 5", parsed.DatacenterID)
+	}
+	if parsed.WorkerID != 42 {
+		t.Errorf("ParseID(id).WorkerID = %d, want 42", parsed.WorkerID)
+	}
+}
@@ -0,0 +1,118 @@
+package snowflake
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// crockfordEncoding is the Crockford Base32 alphabet, chosen over the
+// standard RFC 4648 alphabet because it excludes the easily-confused
+// I, L, O and U characters and is case-insensitive.
+var crockfordEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// base58Alphabet excludes 0, O, I and l, which are easily confused with
+// one another in many fonts.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58BigRadix = big.NewInt(58)
+
+// Base32 returns id encoded as a ~13-character, URL-safe, case-insensitive
+// string using the Crockford Base32 alphabet.
+func (id ID) Base32() string {
+	return crockfordEncoding.EncodeToString(id.bytes())
+}
+
+// ParseBase32 decodes a string produced by ID.Base32 back into an ID.
+// Lowercase letters are accepted.
+func ParseBase32(s string) (ID, error) {
+	b, err := crockfordEncoding.DecodeString(strings.ToUpper(s))
+	if err != nil {
+		return 0, fmt.Errorf("snowflake: invalid base32 ID %q: %w", s, err)
+	}
+	return idFromBytes(b)
+}
+
+// Base64 returns id encoded as an 11-character, URL-safe string using
+// unpadded URL-safe Base64 (RFC 4648 section 5).
+func (id ID) Base64() string {
+	return base64.RawURLEncoding.EncodeToString(id.bytes())
+}
+
+// ParseBase64 decodes a string produced by ID.Base64 back into an ID.
+func ParseBase64(s string) (ID, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("snowflake: invalid base64 ID %q: %w", s, err)
+	}
+	return idFromBytes(b)
+}
+
+// Base58 returns id encoded as a ~11-character string using a Base58
+// alphabet that excludes visually similar characters.
+func (id ID) Base58() string {
+	if id == 0 {
+		return string(base58Alphabet[0])
+	}
+
+	n := new(big.Int).SetUint64(uint64(id))
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base58BigRadix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// out was built least-significant digit first.
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// ParseBase58 decodes a string produced by ID.Base58 back into an ID.
+func ParseBase58(s string) (ID, error) {
+	n := new(big.Int)
+	for _, c := range []byte(s) {
+		idx := indexByte(base58Alphabet, c)
+		if idx < 0 {
+			return 0, fmt.Errorf("snowflake: invalid base58 ID %q", s)
+		}
+		n.Mul(n, base58BigRadix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("snowflake: invalid base58 ID %q: value overflows 64 bits", s)
+	}
+	return ID(n.Uint64()), nil
+}
+
+// bytes returns id as an 8-byte big-endian array.
+func (id ID) bytes() []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
+
+// idFromBytes reverses ID.bytes, accepting any length up to 8 bytes so
+// leading zero bytes dropped by an encoding are tolerated.
+func idFromBytes(b []byte) (ID, error) {
+	if len(b) > 8 {
+		return 0, fmt.Errorf("snowflake: decoded ID is %d bytes, want at most 8", len(b))
+	}
+	var padded [8]byte
+	copy(padded[8-len(b):], b)
+	return ID(binary.BigEndian.Uint64(padded[:])), nil
+}
+
+func indexByte(s string, c byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,79 @@
+package snowflake
+
+import "testing"
+
+func TestIDEncodingRoundTrip(t *testing.T) {
+	ids := []ID{0, 1, 42, 1 << 32, ^ID(0) >> 1, ^ID(0)}
+
+	for _, id := range ids {
+		if got, err := ParseBase32(id.Base32()); err != nil || got != id {
+			t.Errorf("Base32 round trip for %d: got (%d, %v)", id, got, err)
+		}
+		if got, err := ParseBase58(id.Base58()); err != nil || got != id {
+			t.Errorf("Base58 round trip for %d: got (%d, %v)", id, got, err)
+		}
+		if got, err := ParseBase64(id.Base64()); err != nil || got != id {
+			t.Errorf("Base64 round trip for %d: got (%d, %v)", id, got, err)
+		}
+	}
+}
+
+func TestIDBase32Length(t *testing.T) {
+	// A 64-bit value encodes to ceil(64/5) = 13 Crockford Base32 chars.
+	if got := len(ID(^uint64(0)).Base32()); got != 13 {
+		t.Errorf("len(Base32()) = %d, want 13", got)
+	}
+}
+
+func TestIDBase64Length(t *testing.T) {
+	// 8 bytes of unpadded URL-safe Base64 is 11 characters.
+	if got := len(ID(^uint64(0)).Base64()); got != 11 {
+		t.Errorf("len(Base64()) = %d, want 11", got)
+	}
+}
+
+func TestIDBase58ZeroRoundTrip(t *testing.T) {
+	s := ID(0).Base58()
+	if s != "1" {
+		t.Errorf("ID(0).Base58() = %q, want %q", s, "1")
+	}
+	got, err := ParseBase58(s)
+	if err != nil || got != 0 {
+		t.Errorf("ParseBase58(%q) = (%d, %v), want (0, nil)", s, got, err)
+	}
+}
+
+func TestParseBase32Invalid(t *testing.T) {
+	if _, err := ParseBase32("not valid base32!!"); err == nil {
+		t.Error("ParseBase32 on malformed input = nil error, want an error")
+	}
+}
+
+func TestParseBase64Invalid(t *testing.T) {
+	if _, err := ParseBase64("not valid base64!!"); err == nil {
+		t.Error("ParseBase64 on malformed input = nil error, want an error")
+	}
+}
+
+func TestParseBase58Invalid(t *testing.T) {
+	if _, err := ParseBase58("not-base58-0OIl"); err == nil {
+		t.Error("ParseBase58 on input containing excluded characters = nil error, want an error")
+	}
+}
+
+func TestIDFromBytesRejectsOversizedInput(t *testing.T) {
+	if _, err := idFromBytes(make([]byte, 9)); err == nil {
+		t.Error("idFromBytes with 9 bytes = nil error, want an error")
+	}
+}
+
+func TestParseBase58RejectsOverflow(t *testing.T) {
+	// 20 digits of the highest-value base58 character overflows 64 bits.
+	huge := ""
+	for i := 0; i < 20; i++ {
+		huge += string(base58Alphabet[len(base58Alphabet)-1])
+	}
+	if _, err := ParseBase58(huge); err == nil {
+		t.Error("ParseBase58 on an overflowing value = nil error, want an error")
+	}
+}
@@ -0,0 +1,95 @@
+package snowflake
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// ID is a generated snowflake ID. It implements json.Marshaler,
+// json.Unmarshaler, encoding.TextMarshaler, encoding.TextUnmarshaler,
+// sql.Scanner and driver.Valuer so that IDs can be passed directly to
+// json.Marshal/Unmarshal and database/sql without manual conversion.
+type ID uint64
+
+// Next returns a unique ID generated by the snowflake, identical to
+// NextID but returned as the ID type so it can be used directly with
+// JSON and database/sql.
+func (s *Snowflake) Next() ID {
+	return ID(s.NextID())
+}
+
+// Uint64 returns id as a plain uint64, e.g. for callers that want to
+// marshal it as a raw JSON number instead of the default string
+// encoding (JSON numbers above 2^53 lose precision in JavaScript, which
+// is why MarshalJSON emits a string).
+func (id ID) Uint64() uint64 {
+	return uint64(id)
+}
+
+// String returns the base-10 decimal representation of id.
+func (id ID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// MarshalJSON implements json.Marshaler. IDs are encoded as JSON
+// strings rather than numbers because values above 2^53 lose precision
+// when decoded by JavaScript's Number type.
+func (id ID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + id.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts IDs encoded as
+// either JSON strings or JSON numbers.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("snowflake: cannot unmarshal %q into ID: %w", data, err)
+	}
+	*id = ID(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID) UnmarshalText(data []byte) error {
+	v, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("snowflake: cannot unmarshal %q into ID: %w", data, err)
+	}
+	*id = ID(v)
+	return nil
+}
+
+// Value implements driver.Valuer so an ID can be passed directly as a
+// query argument to database/sql.
+func (id ID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// Scan implements sql.Scanner so an ID can be read directly out of a
+// database/sql row.
+func (id *ID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case int64:
+		*id = ID(v)
+		return nil
+	case []byte:
+		return id.UnmarshalText(v)
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case nil:
+		*id = 0
+		return nil
+	default:
+		return fmt.Errorf("snowflake: cannot scan %T into ID", value)
+	}
+}
@@ -0,0 +1,141 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIDMarshalJSONIsString(t *testing.T) {
+	id := ID(1234567890123456789)
+	b, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if got, want := string(b), `"1234567890123456789"`; got != want {
+		t.Errorf("json.Marshal(id) = %s, want %s", got, want)
+	}
+}
+
+func TestIDUnmarshalJSONAcceptsStringAndNumber(t *testing.T) {
+	for _, raw := range []string{`"1234567890123456789"`, `1234567890123456789`} {
+		var id ID
+		if err := json.Unmarshal([]byte(raw), &id); err != nil {
+			t.Fatalf("json.Unmarshal(%s): %v", raw, err)
+		}
+		if id != 1234567890123456789 {
+			t.Errorf("json.Unmarshal(%s) = %d, want 1234567890123456789", raw, id)
+		}
+	}
+}
+
+func TestIDUnmarshalJSONInvalid(t *testing.T) {
+	var id ID
+	if err := json.Unmarshal([]byte(`"not a number"`), &id); err == nil {
+		t.Error("json.Unmarshal on malformed ID = nil error, want an error")
+	}
+}
+
+func TestIDJSONRoundTripInStruct(t *testing.T) {
+	type wrapper struct {
+		ID ID `json:"id"`
+	}
+
+	in := wrapper{ID: 42}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var out wrapper
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if out.ID != in.ID {
+		t.Errorf("round trip through JSON: got %d, want %d", out.ID, in.ID)
+	}
+}
+
+func TestIDMarshalUnmarshalText(t *testing.T) {
+	id := ID(987654321)
+	b, err := id.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+	if string(b) != "987654321" {
+		t.Errorf("MarshalText() = %q, want %q", b, "987654321")
+	}
+
+	var got ID
+	if err := got.UnmarshalText(b); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if got != id {
+		t.Errorf("UnmarshalText round trip = %d, want %d", got, id)
+	}
+}
+
+func TestIDUnmarshalTextInvalid(t *testing.T) {
+	var id ID
+	if err := id.UnmarshalText([]byte("not a number")); err == nil {
+		t.Error("UnmarshalText on malformed input = nil error, want an error")
+	}
+}
+
+func TestIDValue(t *testing.T) {
+	id := ID(42)
+	v, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if v != int64(42) {
+		t.Errorf("Value() = %v (%T), want int64(42)", v, v)
+	}
+}
+
+func TestIDScan(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		want  ID
+	}{
+		{"int64", int64(42), 42},
+		{"bytes", []byte("42"), 42},
+		{"string", "42", 42},
+		{"nil", nil, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var id ID
+			if err := id.Scan(c.value); err != nil {
+				t.Fatalf("Scan(%v): %v", c.value, err)
+			}
+			if id != c.want {
+				t.Errorf("Scan(%v) = %d, want %d", c.value, id, c.want)
+			}
+		})
+	}
+}
+
+func TestIDScanUnsupportedType(t *testing.T) {
+	var id ID
+	if err := id.Scan(3.14); err == nil {
+		t.Error("Scan(float64) = nil error, want an error")
+	}
+}
+
+func TestIDScanValueRoundTrip(t *testing.T) {
+	id := ID(123456789)
+	v, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	var got ID
+	if err := got.Scan(v); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got != id {
+		t.Errorf("Value/Scan round trip = %d, want %d", got, id)
+	}
+}
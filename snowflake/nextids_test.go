@@ -0,0 +1,90 @@
+package snowflake
+
+import "testing"
+
+func TestNextIDsRejectsNonPositiveN(t *testing.T) {
+	s := NewSnowflake(1, 1)
+	for _, n := range []int{0, -1, -100} {
+		if _, err := s.NextIDs(n); err == nil {
+			t.Errorf("NextIDs(%d) = nil error, want an error", n)
+		}
+	}
+}
+
+func TestNextIDsRejectsOverMaxBatchSize(t *testing.T) {
+	s := NewSnowflake(1, 1)
+	s.SetMaxBatchSize(10)
+
+	if _, err := s.NextIDs(10); err != nil {
+		t.Errorf("NextIDs(10) with maxBatchSize 10 = %v, want no error", err)
+	}
+	if _, err := s.NextIDs(11); err == nil {
+		t.Error("NextIDs(11) with maxBatchSize 10 = nil error, want an error")
+	}
+}
+
+// TestNextIDsSequenceRollover forces the per-millisecond sequence
+// counter to exhaust its 4096-value space within a single NextIDs call
+// and checks that the batch rolls over to the next millisecond and
+// keeps producing monotonically increasing (timestamp, sequence)
+// tuples, without ever repeating an ID.
+func TestNextIDsSequenceRollover(t *testing.T) {
+	s := NewSnowflake(1, 1)
+
+	// s.now reports the same millisecond for long enough to exhaust
+	// the sequence counter (4096 values) and trigger the rollover path
+	// in nextIDLocked, then advances by one millisecond.
+	const ms = int64(1000)
+	calls := 0
+	s.now = func() int64 {
+		calls++
+		if calls <= 4097 {
+			return ms
+		}
+		return ms + 1
+	}
+
+	const n = 5000
+	ids, err := s.NextIDs(n)
+	if err != nil {
+		t.Fatalf("NextIDs(%d) returned error: %v", n, err)
+	}
+	if len(ids) != n {
+		t.Fatalf("len(ids) = %d, want %d", len(ids), n)
+	}
+
+	seen := make(map[uint64]bool, n)
+	var prevTimestamp int64
+	var prevSequence uint64
+	for i, id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate ID %d at index %d", id, i)
+		}
+		seen[id] = true
+
+		parsed := s.ParseID(id)
+		ts := parsed.Timestamp.UnixMilli()
+
+		if i == 0 {
+			prevTimestamp, prevSequence = ts, parsed.Sequence
+			continue
+		}
+		if ts < prevTimestamp || (ts == prevTimestamp && parsed.Sequence <= prevSequence) {
+			t.Fatalf("ids not monotonically increasing at index %d: (%d,%d) -> (%d,%d)",
+				i, prevTimestamp, prevSequence, ts, parsed.Sequence)
+		}
+		prevTimestamp, prevSequence = ts, parsed.Sequence
+	}
+
+	// The first 4096 IDs (sequence 0..4095) should all share the first
+	// millisecond; the 4097th ID should have rolled over to the next
+	// millisecond with sequence reset to 0.
+	first := s.ParseID(ids[0])
+	atWrap := s.ParseID(ids[4096])
+	if first.Timestamp.Equal(atWrap.Timestamp) {
+		t.Fatal("expected sequence rollover to advance the timestamp")
+	}
+	if atWrap.Sequence != 0 {
+		t.Fatalf("sequence after rollover = %d, want 0", atWrap.Sequence)
+	}
+}
@@ -0,0 +1,47 @@
+package snowflake
+
+import "testing"
+
+// TestParseIDCustomLayout constructs a Snowflake with a custom epoch
+// and bit widths and checks that ParseID recovers the timestamp,
+// datacenterID, workerID and sequence using that same layout, not the
+// default one.
+func TestParseIDCustomLayout(t *testing.T) {
+	cfg := Config{
+		Epoch:          1609459200000, // 2021-01-01T00:00:00Z in ms
+		DatacenterBits: 4,
+		NodeBits:       6,
+		StepBits:       10,
+	}
+
+	s, err := NewSnowflakeWithConfig(cfg, 9, 37)
+	if err != nil {
+		t.Fatalf("NewSnowflakeWithConfig: %v", err)
+	}
+
+	const fixedMillis = int64(1700000000000)
+	s.now = func() int64 { return fixedMillis }
+
+	id := s.NextID()
+	parsed := s.ParseID(id)
+
+	if got, want := parsed.DatacenterID, uint64(9); got != want {
+		t.Errorf("ParseID(id).DatacenterID = %d, want %d", got, want)
+	}
+	if got, want := parsed.WorkerID, uint64(37); got != want {
+		t.Errorf("ParseID(id).WorkerID = %d, want %d", got, want)
+	}
+	if got, want := parsed.Sequence, uint64(0); got != want {
+		t.Errorf("ParseID(id).Sequence = %d, want %d", got, want)
+	}
+	if got, want := parsed.Timestamp.UnixMilli(), fixedMillis; got != want {
+		t.Errorf("ParseID(id).Timestamp = %d ms, want %d ms", got, want)
+	}
+
+	// Generating a second ID in the same millisecond should bump the
+	// sequence number, and ParseID must recover it under this layout.
+	id2 := s.NextID()
+	if got, want := s.ParseID(id2).Sequence, uint64(1); got != want {
+		t.Errorf("ParseID(id2).Sequence = %d, want %d", got, want)
+	}
+}
@@ -3,6 +3,9 @@
 package snowflake
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 )
 
@@ -10,47 +13,218 @@ var workerIDBits uint64 = 5
 var datacenterIDBits uint64 = 5
 var sequenceBits uint64 = 12
 
-var workerIDShift uint64 = sequenceBits
-var datacenterIDShift uint64 = sequenceBits + workerIDBits
-var timestampLeftShift uint64 = sequenceBits + workerIDBits + datacenterIDBits
-var sequenceMask int64 = int64(1 << sequenceBits) - 1
-
 var twepoch int64 = 1288834974657
 
+// maxBits is the total number of bits available below the timestamp for
+// the datacenter ID, worker ID and sequence number combined.
+const maxBits = 22
+
+// defaultMaxBatchSize is the default limit on the number of IDs a
+// single NextIDs call will generate.
+const defaultMaxBatchSize = 100000
+
+// Config describes the bit layout and epoch a Snowflake should use to
+// generate IDs. The zero value reserves no bits for the datacenter ID,
+// worker ID or sequence number; use DefaultConfig as a starting point.
+type Config struct {
+	// Epoch is the custom epoch, in milliseconds since the Unix epoch,
+	// from which timestamps are measured.
+	Epoch int64
+	// NodeBits is the number of bits reserved for the worker ID.
+	NodeBits uint64
+	// StepBits is the number of bits reserved for the per-millisecond
+	// sequence number.
+	StepBits uint64
+	// DatacenterBits is the number of bits reserved for the datacenter
+	// ID.
+	DatacenterBits uint64
+}
+
+// DefaultConfig returns the Config matching the original, hard-coded
+// Twitter snowflake layout: a 1288834974657 epoch with 5 datacenter
+// bits, 5 worker bits and 12 sequence bits.
+func DefaultConfig() Config {
+	return Config{
+		Epoch:          twepoch,
+		NodeBits:       workerIDBits,
+		StepBits:       sequenceBits,
+		DatacenterBits: datacenterIDBits,
+	}
+}
+
+// ClockDriftPolicy controls how a Snowflake reacts when it observes the
+// wall clock going backward relative to the last timestamp it used to
+// generate an ID.
+type ClockDriftPolicy int
+
+const (
+	// ClockDriftPanic panics as soon as backward clock drift is
+	// detected. This is the historical behavior and the default.
+	ClockDriftPanic ClockDriftPolicy = iota
+	// ClockDriftWait spin-waits until the wall clock catches back up to
+	// the last timestamp used.
+	ClockDriftWait
+	// ClockDriftAdvance advances a logical timestamp 1ms past the last
+	// timestamp used, without waiting on the wall clock.
+	ClockDriftAdvance
+)
+
 // Snowflake struct is responsible for generating unique IDs
 type Snowflake struct {
-	workerID uint64
-	datacenterID uint64
-	sequenceNumber uint64
-	lastTimestamp int64
+	mu               sync.Mutex
+	workerID         uint64
+	datacenterID     uint64
+	sequenceNumber   uint64
+	lastTimestamp    int64
+	clockDriftPolicy ClockDriftPolicy
+
+	epoch int64
+
+	workerIDShift      uint64
+	datacenterIDShift  uint64
+	timestampLeftShift uint64
+	sequenceMask       int64
+
+	nodeBits       uint64
+	stepBits       uint64
+	datacenterBits uint64
+
+	maxBatchSize int
+
+	// now is the clock used to generate timestamps. It defaults to
+	// timeGen; tests substitute a deterministic clock to exercise the
+	// clock-drift and sequence-rollover paths without sleeping on the
+	// real wall clock.
+	now func() int64
 }
 
 // NewSnowflake is the constructor for snowflakes. It is considered a
-// programming error to create a Snowflake by any other means.
+// programming error to create a Snowflake by any other means. It uses
+// the original Twitter bit layout and epoch; use NewSnowflakeWithConfig
+// to customize them.
 func NewSnowflake(datacenterID uint64, workerID uint64) *Snowflake {
+	s, err := NewSnowflakeWithConfig(DefaultConfig(), datacenterID, workerID)
+	if err != nil {
+		// DefaultConfig is always valid, so this can't happen.
+		panic(err)
+	}
+	return s
+}
+
+// NewSnowflakeWithConfig constructs a Snowflake using a custom bit
+// layout and epoch. It returns an error if the configured bit widths
+// don't leave room for a sane layout, or if datacenterID/workerID don't
+// fit within their configured widths.
+func NewSnowflakeWithConfig(cfg Config, datacenterID uint64, workerID uint64) (*Snowflake, error) {
+	if cfg.DatacenterBits+cfg.NodeBits+cfg.StepBits > maxBits {
+		return nil, errors.New("snowflake: DatacenterBits + NodeBits + StepBits exceeds 22 bits")
+	}
+
+	maxDatacenterID := uint64(1<<cfg.DatacenterBits) - 1
+	if datacenterID > maxDatacenterID {
+		return nil, errors.New("snowflake: datacenterID does not fit in the configured DatacenterBits")
+	}
+
+	maxWorkerID := uint64(1<<cfg.NodeBits) - 1
+	if workerID > maxWorkerID {
+		return nil, errors.New("snowflake: workerID does not fit in the configured NodeBits")
+	}
+
 	s := new(Snowflake)
 	s.datacenterID = datacenterID
 	s.workerID = workerID
 	s.sequenceNumber = 0
 	s.lastTimestamp = 0
-	return s
+	s.clockDriftPolicy = ClockDriftPanic
+	s.maxBatchSize = defaultMaxBatchSize
+	s.now = timeGen
+
+	s.epoch = cfg.Epoch
+	s.nodeBits = cfg.NodeBits
+	s.stepBits = cfg.StepBits
+	s.datacenterBits = cfg.DatacenterBits
+
+	s.workerIDShift = s.stepBits
+	s.datacenterIDShift = s.stepBits + s.nodeBits
+	s.timestampLeftShift = s.stepBits + s.nodeBits + s.datacenterBits
+	s.sequenceMask = int64(1<<s.stepBits) - 1
+
+	return s, nil
+}
+
+// SetClockDriftPolicy configures how s reacts when backward clock drift
+// is detected. The default is ClockDriftPanic.
+func (s *Snowflake) SetClockDriftPolicy(policy ClockDriftPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clockDriftPolicy = policy
+}
+
+// SetMaxBatchSize configures the largest n that NextIDs will accept in
+// a single call. The default is defaultMaxBatchSize.
+func (s *Snowflake) SetMaxBatchSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxBatchSize = n
 }
 
 // NextID returns a unique id value generated by the
 // snowflake. Calling NextId multiple times on the same snowflake will
-// return unique, monotonically increasing IDs.
+// return unique, monotonically increasing IDs. NextID is safe to call
+// concurrently from multiple goroutines.
 func (s *Snowflake) NextID() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nextIDLocked()
+}
+
+// NextIDs generates n IDs in one lock acquisition, which is
+// considerably cheaper than calling NextID n times when producing IDs
+// in bulk (backfills, bulk inserts, event fan-out). The sequence
+// counter rolls over and the timestamp advances as needed when the
+// per-millisecond limit is hit, so the returned IDs share the
+// snowflake's datacenter/worker but have monotonically increasing
+// (timestamp, sequence) tuples. It returns an error instead of
+// generating anything if n is not positive or exceeds the configured
+// maximum batch size (see SetMaxBatchSize).
+func (s *Snowflake) NextIDs(n int) ([]uint64, error) {
+	if n <= 0 {
+		return nil, errors.New("snowflake: n must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	timestamp := timeGen()
+	if n > s.maxBatchSize {
+		return nil, fmt.Errorf("snowflake: n (%d) exceeds maximum batch size (%d)", n, s.maxBatchSize)
+	}
+
+	ids := make([]uint64, n)
+	for i := 0; i < n; i++ {
+		ids[i] = s.nextIDLocked()
+	}
+	return ids, nil
+}
+
+// nextIDLocked generates a single ID. Callers must hold s.mu.
+func (s *Snowflake) nextIDLocked() uint64 {
+	timestamp := s.now()
 
 	if (timestamp < s.lastTimestamp) {
-		panic("clock is going backward!")
+		switch s.clockDriftPolicy {
+		case ClockDriftWait:
+			timestamp = s.tilNextMillis(s.lastTimestamp - 1)
+		case ClockDriftAdvance:
+			timestamp = s.lastTimestamp + 1
+		default:
+			panic("clock is going backward!")
+		}
 	}
 
 	if (s.lastTimestamp == timestamp) {
-		s.sequenceNumber = uint64((s.sequenceNumber + 1) & uint64(sequenceMask))
+		s.sequenceNumber = uint64((s.sequenceNumber + 1) & uint64(s.sequenceMask))
 		if (s.sequenceNumber == 0) {
-			timestamp = tilNextMillis(s.lastTimestamp)
+			timestamp = s.tilNextMillis(s.lastTimestamp)
 		}
 	} else {
 		s.sequenceNumber = 0
@@ -58,9 +232,9 @@ func (s *Snowflake) NextID() uint64 {
 
 	s.lastTimestamp = timestamp
 
-	return uint64(((timestamp - twepoch) << timestampLeftShift)) | 
-		(s.datacenterID << datacenterIDShift) |
-		(s.workerID << workerIDShift) |
+	return uint64(((timestamp - s.epoch) << s.timestampLeftShift)) |
+		(s.datacenterID << s.datacenterIDShift) |
+		(s.workerID << s.workerIDShift) |
 		s.sequenceNumber
 }
 
@@ -75,6 +249,33 @@ func (s *Snowflake) WorkerID() uint64 {
 	return s.workerID
 }
 
+// ParsedID holds the components that make up a generated ID, as
+// recovered by ParseID.
+type ParsedID struct {
+	Timestamp    time.Time
+	DatacenterID uint64
+	WorkerID     uint64
+	Sequence     uint64
+}
+
+// ParseID decomposes an ID previously generated by s back into its
+// timestamp, datacenterID, workerID and sequence number. It uses s's
+// own bit layout and epoch, so an ID must be parsed with a Snowflake
+// configured the same way as the one that generated it.
+func (s *Snowflake) ParseID(id uint64) ParsedID {
+	datacenterMask := uint64(1<<s.datacenterBits) - 1
+	workerMask := uint64(1<<s.nodeBits) - 1
+
+	millis := int64(id>>s.timestampLeftShift) + s.epoch
+
+	return ParsedID{
+		Timestamp:    time.Unix(0, millis*int64(time.Millisecond)),
+		DatacenterID: (id >> s.datacenterIDShift) & datacenterMask,
+		WorkerID:     (id >> s.workerIDShift) & workerMask,
+		Sequence:     id & uint64(s.sequenceMask),
+	}
+}
+
 
 //// package private
 
@@ -84,10 +285,12 @@ func timeGen() int64 {
 	return millis
 }
 
-func tilNextMillis(lastTimestamp int64) int64 {
-	timestamp := timeGen()
+// tilNextMillis spins, polling s.now, until it reports a timestamp past
+// lastTimestamp.
+func (s *Snowflake) tilNextMillis(lastTimestamp int64) int64 {
+	timestamp := s.now()
 	for timestamp <= lastTimestamp {
-		timestamp = timeGen()
+		timestamp = s.now()
 	}
 	return timestamp
 }
@@ -0,0 +1,103 @@
+package snowflake
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNextIDClockDriftPanic(t *testing.T) {
+	s := NewSnowflake(1, 1)
+	s.lastTimestamp = 1000
+	s.now = func() int64 { return 999 }
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected NextID to panic on backward clock drift")
+		}
+	}()
+	s.NextID()
+}
+
+func TestNextIDClockDriftWait(t *testing.T) {
+	s := NewSnowflake(1, 1)
+	s.SetClockDriftPolicy(ClockDriftWait)
+	s.lastTimestamp = 1000
+
+	calls := 0
+	s.now = func() int64 {
+		calls++
+		if calls < 3 {
+			return 999 // behind lastTimestamp; NextID should keep polling
+		}
+		return 1001 // clock has caught back up
+	}
+
+	s.NextID()
+	if s.lastTimestamp != 1001 {
+		t.Fatalf("lastTimestamp = %d, want 1001", s.lastTimestamp)
+	}
+	if calls < 3 {
+		t.Fatalf("s.now called %d times, want at least 3 (spin-wait until clock catches up)", calls)
+	}
+}
+
+func TestNextIDClockDriftAdvance(t *testing.T) {
+	s := NewSnowflake(1, 1)
+	s.SetClockDriftPolicy(ClockDriftAdvance)
+	s.lastTimestamp = 1000
+	s.now = func() int64 { return 999 }
+
+	s.NextID()
+	if s.lastTimestamp != 1001 {
+		t.Fatalf("lastTimestamp = %d, want 1001 (lastTimestamp+1)", s.lastTimestamp)
+	}
+}
+
+// TestNextIDConcurrentUnique drives NextID from many goroutines
+// simultaneously and checks that every returned ID is unique and that
+// the run completes promptly, guarding against both duplicate IDs and
+// a deadlock/busy-loop in the mutex or clock-drift handling.
+func TestNextIDConcurrentUnique(t *testing.T) {
+	s := NewSnowflake(1, 1)
+
+	const goroutines = 50
+	const perGoroutine = 200
+
+	var wg sync.WaitGroup
+	ids := make(chan uint64, goroutines*perGoroutine)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				ids <- s.NextID()
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+		close(ids)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for concurrent NextID calls; possible deadlock")
+	}
+
+	seen := make(map[uint64]bool, goroutines*perGoroutine)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate ID %d produced under concurrent access", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != goroutines*perGoroutine {
+		t.Fatalf("got %d unique IDs, want %d", len(seen), goroutines*perGoroutine)
+	}
+}
@@ -0,0 +1,97 @@
+package snowflake
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"strconv"
+)
+
+// WorkerIDFromMAC derives a worker ID, masked to fit in bits bits, from
+// the hardware address of the first non-loopback network interface it
+// finds. This avoids having to hand-assign a worker ID at every
+// deploy, at the cost of a small chance of collision between machines
+// whose MAC addresses hash to the same value; pass the NodeBits of the
+// Config a Snowflake will be built with so the derived ID always fits.
+func WorkerIDFromMAC(bits uint64) (uint64, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return 0, fmt.Errorf("snowflake: could not list network interfaces: %w", err)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		if len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		return hashToWorkerID(iface.HardwareAddr, bits), nil
+	}
+
+	return 0, fmt.Errorf("snowflake: no non-loopback network interface with a hardware address found")
+}
+
+// WorkerIDFromHostname derives a worker ID, masked to fit in bits bits,
+// from os.Hostname. In container/Kubernetes environments the hostname
+// is typically the pod name, which is unique per instance.
+func WorkerIDFromHostname(bits uint64) (uint64, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return 0, fmt.Errorf("snowflake: could not read hostname: %w", err)
+	}
+	return hashToWorkerID([]byte(host), bits), nil
+}
+
+// WorkerIDFromEnv derives a worker ID, masked to fit in bits bits, from
+// the environment variable varName. If the variable holds a plain
+// integer it is used directly (masked to fit bits); otherwise its
+// value is hashed the same way as WorkerIDFromMAC and
+// WorkerIDFromHostname.
+func WorkerIDFromEnv(varName string, bits uint64) (uint64, error) {
+	v, ok := os.LookupEnv(varName)
+	if !ok {
+		return 0, fmt.Errorf("snowflake: environment variable %q is not set", varName)
+	}
+
+	if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+		return n & bitMask(bits), nil
+	}
+	return hashToWorkerID([]byte(v), bits), nil
+}
+
+// NewSnowflakeAuto constructs a Snowflake using the original Twitter bit
+// layout and epoch, deriving its worker ID automatically: it first
+// tries WorkerIDFromMAC, falling back to WorkerIDFromHostname if no
+// usable network interface is found.
+func NewSnowflakeAuto(datacenterID uint64) (*Snowflake, error) {
+	return NewSnowflakeAutoWithConfig(DefaultConfig(), datacenterID)
+}
+
+// NewSnowflakeAutoWithConfig is NewSnowflakeAuto for a custom bit
+// layout and epoch: the worker ID is derived to fit cfg.NodeBits, so it
+// composes correctly with NewSnowflakeWithConfig's configurable
+// layout instead of assuming the default 5-bit worker ID space.
+func NewSnowflakeAutoWithConfig(cfg Config, datacenterID uint64) (*Snowflake, error) {
+	workerID, err := WorkerIDFromMAC(cfg.NodeBits)
+	if err != nil {
+		workerID, err = WorkerIDFromHostname(cfg.NodeBits)
+		if err != nil {
+			return nil, fmt.Errorf("snowflake: could not auto-derive a worker ID: %w", err)
+		}
+	}
+	return NewSnowflakeWithConfig(cfg, datacenterID, workerID)
+}
+
+// hashToWorkerID hashes b down to a value that fits in bits bits.
+func hashToWorkerID(b []byte, bits uint64) uint64 {
+	h := fnv.New32a()
+	h.Write(b)
+	return uint64(h.Sum32()) & bitMask(bits)
+}
+
+// bitMask returns a mask with the low bits bits set.
+func bitMask(bits uint64) uint64 {
+	return uint64(1<<bits) - 1
+}
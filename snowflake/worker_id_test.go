@@ -0,0 +1,63 @@
+package snowflake
+
+import "testing"
+
+func TestHashToWorkerIDFitsBitWidth(t *testing.T) {
+	for _, bits := range []uint64{3, 5, 10, 16} {
+		max := bitMask(bits)
+		for _, input := range [][]byte{[]byte("host-a"), []byte("host-b"), []byte("00:11:22:33:44:55")} {
+			got := hashToWorkerID(input, bits)
+			if got > max {
+				t.Errorf("hashToWorkerID(%q, %d) = %d, exceeds %d-bit max %d", input, bits, got, bits, max)
+			}
+		}
+	}
+}
+
+func TestWorkerIDFromEnvRespectsBitWidth(t *testing.T) {
+	const varName = "SNOWFLAKE_TEST_WORKER_ID"
+
+	t.Setenv(varName, "123")
+	got, err := WorkerIDFromEnv(varName, 3)
+	if err != nil {
+		t.Fatalf("WorkerIDFromEnv: %v", err)
+	}
+	if want := uint64(123) & bitMask(3); got != want {
+		t.Errorf("WorkerIDFromEnv(%q, 3) = %d, want %d (masked to 3 bits)", varName, got, want)
+	}
+	if got > bitMask(3) {
+		t.Errorf("WorkerIDFromEnv(%q, 3) = %d, exceeds 3-bit max %d", varName, got, bitMask(3))
+	}
+}
+
+func TestWorkerIDFromEnvHashesNonNumericValue(t *testing.T) {
+	const varName = "SNOWFLAKE_TEST_WORKER_ID_NONNUMERIC"
+	t.Setenv(varName, "pod-7f8c9d")
+
+	got, err := WorkerIDFromEnv(varName, 5)
+	if err != nil {
+		t.Fatalf("WorkerIDFromEnv: %v", err)
+	}
+	if got > bitMask(5) {
+		t.Errorf("WorkerIDFromEnv(%q, 5) = %d, exceeds 5-bit max %d", varName, got, bitMask(5))
+	}
+}
+
+func TestWorkerIDFromEnvMissing(t *testing.T) {
+	if _, err := WorkerIDFromEnv("SNOWFLAKE_TEST_WORKER_ID_MISSING", 5); err == nil {
+		t.Error("WorkerIDFromEnv on unset variable = nil error, want an error")
+	}
+}
+
+func TestNewSnowflakeAutoWithConfigDerivesFittingWorkerID(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NodeBits = 3 // much narrower than the default 5 bits
+
+	s, err := NewSnowflakeAutoWithConfig(cfg, 0)
+	if err != nil {
+		t.Fatalf("NewSnowflakeAutoWithConfig: %v", err)
+	}
+	if s.WorkerID() > bitMask(cfg.NodeBits) {
+		t.Errorf("derived WorkerID() = %d, exceeds %d-bit max %d", s.WorkerID(), cfg.NodeBits, bitMask(cfg.NodeBits))
+	}
+}